@@ -0,0 +1,67 @@
+// Package changelog parses a Keep a Changelog (https://keepachangelog.com)
+// formatted CHANGELOG.md into its versioned sections, so release-changelog
+// can reuse hand-written release notes instead of only synthesising them
+// from commits.
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var headingRe = regexp.MustCompile(`^##\s+\[?v?(\d+\.\d+\.\d+[^\]]*)\]?`)
+
+// Entry is a single versioned section of a changelog.
+type Entry struct {
+	Version string
+	Title   string
+	Body    string
+}
+
+type section struct {
+	version   string
+	title     string
+	startLine int
+	endLine   int
+}
+
+// Parse splits content into its versioned sections.
+func Parse(content string) []Entry {
+	lines := strings.Split(content, "\n")
+
+	var sections []section
+	for i, line := range lines {
+		m := headingRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if n := len(sections); n > 0 {
+			sections[n-1].endLine = i
+		}
+		sections = append(sections, section{version: m[1], title: line, startLine: i, endLine: len(lines)})
+	}
+
+	entries := make([]Entry, 0, len(sections))
+	for _, s := range sections {
+		body := strings.TrimSpace(strings.Join(lines[s.startLine+1:s.endLine], "\n"))
+		entries = append(entries, Entry{Version: s.version, Title: s.title, Body: body})
+	}
+	return entries
+}
+
+// Find returns the entry matching tag (with or without a leading "v"), and
+// whether one was found.
+func Find(content, tag string) (Entry, bool) {
+	version := strings.TrimPrefix(tag, "v")
+	for _, entry := range Parse(content) {
+		if entry.Version == version {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// ErrNotFound is returned by callers that need to distinguish "no
+// CHANGELOG.md section for this tag" from other failures.
+var ErrNotFound = fmt.Errorf("changelog: no section found for tag")
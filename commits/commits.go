@@ -0,0 +1,110 @@
+// Package commits classifies commit messages using the Conventional
+// Commits (https://www.conventionalcommits.org) grammar and derives a
+// semver bump from the result.
+package commits
+
+import (
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var headlineRe = regexp.MustCompile(`^(\w+)(\(([^)]+)\))?(!)?:\s*(.*)$`)
+var breakingFooterRe = regexp.MustCompile(`(?m)^BREAKING CHANGE:`)
+
+// Parsed is a single commit classified per the Conventional Commits spec.
+type Parsed struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+}
+
+// Parse classifies a commit's headline and (optional) body. Commits that
+// don't match the Conventional Commits grammar come back with an empty
+// Type and the full headline as Subject.
+func Parse(headline, body string) Parsed {
+	m := headlineRe.FindStringSubmatch(headline)
+	if m == nil {
+		return Parsed{Subject: headline}
+	}
+
+	return Parsed{
+		Type:     m[1],
+		Scope:    m[3],
+		Breaking: m[4] == "!" || breakingFooterRe.MatchString(body),
+		Subject:  m[5],
+	}
+}
+
+// Section titles. The order they are rendered in is given by sectionOrder.
+const (
+	SectionFeatures = "Features"
+	SectionFixes    = "Bug Fixes"
+	SectionBreaking = "BREAKING CHANGES"
+	SectionOther    = "Other Changes"
+)
+
+// sectionOrder is the stable rendering order for Group's output.
+var sectionOrder = []string{SectionBreaking, SectionFeatures, SectionFixes, SectionOther}
+
+// sectionFor returns the section a parsed commit belongs under. A breaking
+// commit is always called out under SectionBreaking in addition to its
+// normal section.
+func sectionFor(p Parsed) string {
+	switch p.Type {
+	case "feat":
+		return SectionFeatures
+	case "fix":
+		return SectionFixes
+	default:
+		return SectionOther
+	}
+}
+
+// Group buckets parsed commits into their Conventional Commits sections and
+// returns the section titles in the stable order they should be rendered,
+// along with the commits belonging to each.
+func Group(parsed []Parsed) ([]string, map[string][]Parsed) {
+	buckets := map[string][]Parsed{}
+
+	for _, p := range parsed {
+		section := sectionFor(p)
+		buckets[section] = append(buckets[section], p)
+		if p.Breaking && section != SectionBreaking {
+			buckets[SectionBreaking] = append(buckets[SectionBreaking], p)
+		}
+	}
+
+	var sections []string
+	for _, s := range sectionOrder {
+		if len(buckets[s]) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections, buckets
+}
+
+// Bump computes the next semver version for current given the set of
+// commits since the last release: a major bump on any breaking commit, a
+// minor bump on any feat commit, and a patch bump otherwise.
+func Bump(current *semver.Version, parsed []Parsed) semver.Version {
+	var major, minor bool
+	for _, p := range parsed {
+		if p.Breaking {
+			major = true
+		}
+		if p.Type == "feat" {
+			minor = true
+		}
+	}
+
+	switch {
+	case major:
+		return current.IncMajor()
+	case minor:
+		return current.IncMinor()
+	default:
+		return current.IncPatch()
+	}
+}
@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Discord posts release announcements to a Discord incoming webhook.
+type Discord struct {
+	WebhookURL string
+}
+
+// NewDiscord returns a Notifier that posts to a Discord incoming webhook.
+func NewDiscord(webhookURL string) *Discord {
+	return &Discord{WebhookURL: webhookURL}
+}
+
+type discordPayload struct {
+	Content string `json:"content"`
+}
+
+// Notify posts release as a Discord message naming the repo, tag, and
+// author, followed by the changelog body.
+func (d *Discord) Notify(ctx context.Context, release Release, ref RepoRef) error {
+	content := fmt.Sprintf("**%s/%s %s** released by %s\n%s", ref.Owner, ref.Repo, release.Tag, release.Author, release.Body)
+	return postJSON(ctx, d.WebhookURL, discordPayload{Content: content})
+}
@@ -0,0 +1,35 @@
+package notify
+
+import "context"
+
+// Generic posts a plain JSON payload describing the release to an
+// arbitrary webhook URL, for targets without a dedicated implementation.
+type Generic struct {
+	WebhookURL string
+}
+
+// NewGeneric returns a Notifier that posts a generic JSON payload to
+// webhookURL.
+func NewGeneric(webhookURL string) *Generic {
+	return &Generic{WebhookURL: webhookURL}
+}
+
+type genericPayload struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Tag    string `json:"tag"`
+	Author string `json:"author"`
+	Body   string `json:"body"`
+}
+
+// Notify posts release as a generic JSON payload to the configured
+// webhook URL.
+func (g *Generic) Notify(ctx context.Context, release Release, ref RepoRef) error {
+	return postJSON(ctx, g.WebhookURL, genericPayload{
+		Owner:  ref.Owner,
+		Repo:   ref.Repo,
+		Tag:    release.Tag,
+		Author: release.Author,
+		Body:   release.Body,
+	})
+}
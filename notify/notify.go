@@ -0,0 +1,75 @@
+// Package notify delivers release announcements to chat and generic
+// webhook targets after a release is published, so a team can react to a
+// publish without polling the SCM.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// RepoRef identifies the repository a release belongs to.
+type RepoRef struct {
+	Owner string
+	Repo  string
+}
+
+// Release is the subset of release data a Notifier needs to render an
+// announcement.
+type Release struct {
+	Tag    string
+	Body   string
+	Author string
+}
+
+// Notifier delivers a release announcement to a single target.
+type Notifier interface {
+	// Notify sends an announcement for release to the configured target.
+	Notify(ctx context.Context, release Release, ref RepoRef) error
+}
+
+// New returns the Notifier implementation for kind, posting to webhookURL.
+func New(kind, webhookURL string) (Notifier, error) {
+	switch kind {
+	case "slack":
+		return NewSlack(webhookURL), nil
+	case "discord":
+		return NewDiscord(webhookURL), nil
+	case "teams":
+		return NewTeams(webhookURL), nil
+	case "webhook":
+		return NewGeneric(webhookURL), nil
+	default:
+		return nil, fmt.Errorf("notify: unknown notifier %q", kind)
+	}
+}
+
+// postJSON marshals payload and POSTs it to url, returning an error if the
+// request can't be built, fails, or the target responds with a non-2xx/3xx
+// status.
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("notify: %s returned %s", url, resp.Status)
+	}
+	return nil
+}
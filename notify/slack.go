@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Slack posts release announcements to a Slack incoming webhook, rendered
+// as a blocks message.
+type Slack struct {
+	WebhookURL string
+}
+
+// NewSlack returns a Notifier that posts to a Slack incoming webhook.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{WebhookURL: webhookURL}
+}
+
+type slackPayload struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Notify posts release as a Slack blocks message naming the repo, tag, and
+// author, with the changelog body below, matching the pattern used by
+// release-notification tools in the ecosystem.
+func (s *Slack) Notify(ctx context.Context, release Release, ref RepoRef) error {
+	header := fmt.Sprintf("*%s/%s %s* released by %s", ref.Owner, ref.Repo, release.Tag, release.Author)
+
+	payload := slackPayload{
+		Blocks: []slackBlock{
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: header}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: release.Body}},
+		},
+	}
+
+	return postJSON(ctx, s.WebhookURL, payload)
+}
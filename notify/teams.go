@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Teams posts release announcements to a Microsoft Teams incoming webhook
+// connector.
+type Teams struct {
+	WebhookURL string
+}
+
+// NewTeams returns a Notifier that posts to a Microsoft Teams incoming
+// webhook connector.
+func NewTeams(webhookURL string) *Teams {
+	return &Teams{WebhookURL: webhookURL}
+}
+
+type teamsPayload struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Text    string `json:"text"`
+}
+
+// Notify posts release as a Teams MessageCard naming the repo, tag, and
+// author, followed by the changelog body.
+func (t *Teams) Notify(ctx context.Context, release Release, ref RepoRef) error {
+	summary := fmt.Sprintf("%s/%s %s released", ref.Owner, ref.Repo, release.Tag)
+	text := fmt.Sprintf("**%s** released by %s\n\n%s", summary, release.Author, release.Body)
+
+	return postJSON(ctx, t.WebhookURL, teamsPayload{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Summary: summary,
+		Text:    text,
+	})
+}
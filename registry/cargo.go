@@ -0,0 +1,28 @@
+package registry
+
+import "context"
+
+const cargoAPI = "https://crates.io/api/v1/crates"
+
+// Cargo looks up the latest version published to crates.io.
+type Cargo struct{}
+
+// NewCargo returns a Registry backed by the public crates.io API.
+func NewCargo() *Cargo {
+	return &Cargo{}
+}
+
+type cargoPackage struct {
+	Crate struct {
+		MaxStableVersion string `json:"max_stable_version"`
+	} `json:"crate"`
+}
+
+// LatestVersion returns crate.max_stable_version for the named crate.
+func (c *Cargo) LatestVersion(ctx context.Context, name string) (string, error) {
+	var pkg cargoPackage
+	if err := getJSON(ctx, cargoAPI+"/"+name, &pkg); err != nil {
+		return "", err
+	}
+	return pkg.Crate.MaxStableVersion, nil
+}
@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const dockerHubAPI = "https://hub.docker.com/v2/repositories"
+
+// dockerHubPageSize is how many of the most recently pushed tags to
+// consider when looking for a semver one. Docker Hub has no "order by
+// version" mode, and most repos push moving tags (latest, a branch name,
+// a short sha, ...) far more often than a version tag, so a single page
+// ordered by last_updated is usually just the moving "latest" tag.
+const dockerHubPageSize = 100
+
+// DockerHub looks up the most recently pushed semver-looking tag of a
+// Docker Hub repository, used as its "latest version" for tag inference.
+type DockerHub struct{}
+
+// NewDockerHub returns a Registry backed by the public Docker Hub API.
+func NewDockerHub() *DockerHub {
+	return &DockerHub{}
+}
+
+type dockerHubTagsResponse struct {
+	Results []struct {
+		Name string `json:"name"`
+	} `json:"results"`
+}
+
+// LatestVersion returns the name of the most recently updated, semver-
+// parseable tag of the Docker Hub repository identified by name, given as
+// "owner/repo". Moving tags such as "latest" or a branch name are common
+// on Docker Hub and aren't valid versions, so they're skipped; a repo that
+// never pushes a semver tag won't be inferable this way.
+func (d *DockerHub) LatestVersion(ctx context.Context, name string) (string, error) {
+	var resp dockerHubTagsResponse
+	url := fmt.Sprintf("%s/%s/tags?page_size=%d&ordering=last_updated", dockerHubAPI, name, dockerHubPageSize)
+	if err := getJSON(ctx, url, &resp); err != nil {
+		return "", err
+	}
+
+	for _, tag := range resp.Results {
+		if _, err := semver.NewVersion(tag.Name); err == nil {
+			return tag.Name, nil
+		}
+	}
+	return "", fmt.Errorf("registry: no semver-looking tag found among the %d most recently updated tags for Docker Hub repository %q", len(resp.Results), name)
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const mavenSearchAPI = "https://search.maven.org/solrsearch/select"
+
+// Maven looks up the latest version published to Maven Central.
+type Maven struct{}
+
+// NewMaven returns a Registry backed by the Maven Central search API.
+func NewMaven() *Maven {
+	return &Maven{}
+}
+
+type mavenSearchResponse struct {
+	Response struct {
+		Docs []struct {
+			Version string `json:"v"`
+		} `json:"docs"`
+	} `json:"response"`
+}
+
+// LatestVersion returns the latest version of the Maven Central artifact
+// identified by name, given as "groupId:artifactId".
+func (m *Maven) LatestVersion(ctx context.Context, name string) (string, error) {
+	group, artifact, ok := splitCoordinate(name)
+	if !ok {
+		return "", fmt.Errorf("registry: maven package name must be %q, got %q", "groupId:artifactId", name)
+	}
+
+	query := url.Values{}
+	query.Set("q", fmt.Sprintf("g:%s AND a:%s", group, artifact))
+	query.Set("core", "gav")
+	query.Set("rows", "1")
+	query.Set("wt", "json")
+
+	var resp mavenSearchResponse
+	if err := getJSON(ctx, mavenSearchAPI+"?"+query.Encode(), &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Response.Docs) == 0 {
+		return "", fmt.Errorf("registry: no Maven Central artifact found for %q", name)
+	}
+	return resp.Response.Docs[0].Version, nil
+}
+
+func splitCoordinate(name string) (group, artifact string, ok bool) {
+	parts := strings.SplitN(name, ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
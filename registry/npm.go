@@ -0,0 +1,34 @@
+package registry
+
+import "context"
+
+const defaultNPMRegistry = "https://registry.npmjs.org"
+
+// NPM looks up the latest version published to an npm registry.
+type NPM struct {
+	Server string
+}
+
+// NewNPM returns a Registry backed by server, the npm registry's base
+// URL. An empty server selects the public npm registry.
+func NewNPM(server string) *NPM {
+	if server == "" {
+		server = defaultNPMRegistry
+	}
+	return &NPM{Server: server}
+}
+
+type npmPackage struct {
+	DistTags struct {
+		Latest string `json:"latest"`
+	} `json:"dist-tags"`
+}
+
+// LatestVersion returns dist-tags.latest for the named npm package.
+func (n *NPM) LatestVersion(ctx context.Context, name string) (string, error) {
+	var pkg npmPackage
+	if err := getJSON(ctx, n.Server+"/"+name, &pkg); err != nil {
+		return "", err
+	}
+	return pkg.DistTags.Latest, nil
+}
@@ -0,0 +1,28 @@
+package registry
+
+import "context"
+
+const pypiAPI = "https://pypi.org/pypi"
+
+// PyPI looks up the latest version published to pypi.org.
+type PyPI struct{}
+
+// NewPyPI returns a Registry backed by the public PyPI JSON API.
+func NewPyPI() *PyPI {
+	return &PyPI{}
+}
+
+type pypiPackage struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+// LatestVersion returns info.version for the named PyPI package.
+func (p *PyPI) LatestVersion(ctx context.Context, name string) (string, error) {
+	var pkg pypiPackage
+	if err := getJSON(ctx, pypiAPI+"/"+name+"/json", &pkg); err != nil {
+		return "", err
+	}
+	return pkg.Info.Version, nil
+}
@@ -0,0 +1,36 @@
+// Package registry looks up the latest published version of a package
+// across several package registries, so release-changelog can infer the
+// next semver tag for repos that aren't published to npm.
+package registry
+
+import (
+	"context"
+	"fmt"
+)
+
+// Registry looks up the latest published version of a package.
+type Registry interface {
+	// LatestVersion returns the most recently published version of the
+	// package identified by name.
+	LatestVersion(ctx context.Context, name string) (string, error)
+}
+
+// New returns the Registry implementation for kind. server overrides the
+// registry's API base URL; it's only meaningful for npm, which may be
+// self-hosted. An empty kind selects npm.
+func New(kind, server string) (Registry, error) {
+	switch kind {
+	case "", "npm":
+		return NewNPM(server), nil
+	case "pypi":
+		return NewPyPI(), nil
+	case "maven":
+		return NewMaven(), nil
+	case "cargo":
+		return NewCargo(), nil
+	case "docker":
+		return NewDockerHub(), nil
+	default:
+		return nil, fmt.Errorf("registry: unknown kind %q", kind)
+	}
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -11,150 +9,89 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/confluentinc/confluent-kafka-go/kafka"
-	"github.com/machinebox/graphql"
+	"github.com/karidea/release-changelog/changelog"
+	"github.com/karidea/release-changelog/commits"
+	"github.com/karidea/release-changelog/notify"
+	"github.com/karidea/release-changelog/registry"
+	"github.com/karidea/release-changelog/remote"
+	"github.com/karidea/release-changelog/webhook"
 )
 
-var PullRequestQuery = `
-query($owner: String!, $repo: String!, $pr: Int!) {
-  repository(owner: $owner, name: $repo) {
-    name
-    pullRequest(number: $pr) {
-      title baseRefName author { login } baseRefOid headRefOid createdAt
-      commits(first: 50) {
-        nodes {
-          commit {
-            messageHeadline
-            abbreviatedOid
-            author { user { login } }
-            associatedPullRequests(first: 1) {
-              nodes {
-                number
-              }
-            }
-          }
-        }
-      }
-    }
-  }
-}
-`
-
-var NpmPackageJsonQuery = `
-query($owner: String!, $repo: String!) {
-  repository(owner: $owner, name: $repo) {
-    object(expression: "master:package.json") {
-      ... on Blob {
-        text
-      }
-    }
-  }
-}
-`
-
-type User struct {
-	Login string
-}
-
-type Author struct {
-	User User
-}
-
-type PullRequestAuthor struct {
-	Login string
-}
-
-type AssociatedPullRequest struct {
-	Number int
-}
-
-type AssociatedPullRequests struct {
-	Nodes []AssociatedPullRequest `json:"nodes"`
-}
-
-type Commit struct {
-	MessageHeadline        string                 `json:"messageHeadline"`
-	AbbreviatedOid         string                 `json:"abbreviatedOid"`
-	Author                 Author                 `json:"author"`
-	AssociatedPullRequests AssociatedPullRequests `json:"associatedPullRequests"`
-}
-
-type CommitNodes struct {
-	Commit Commit
-}
-
-type Commits struct {
-	Nodes []CommitNodes
-}
-
-type PullRequest struct {
-	Title       string            `json:"title"`
-	CreatedAt   string            `json:"createdAt"`
-	BaseRefName string            `json:"baseRefName"`
-	HeadRefOid  string            `json:"headRefOid"`
-	Author      PullRequestAuthor `json:"author"`
-	Commits     Commits           `json:"commits"`
-}
-
-type Object struct {
-	Text string `json:"text"`
-}
-
-type Repository struct {
-	Name        string      `json:"name"`
-	PullRequest PullRequest `json:"pullRequest"`
-	Object      Object      `json:"object"`
-}
-
-type QueryResponse struct {
-	Repository Repository `json:"repository"`
-}
-
-type Release struct {
-	TagName         string `json:"tag_name"`
-	TargetCommitish string `json:"target_commitish"`
-	Name            string `json:"name"`
-	Body            string `json:"body"`
-}
-
-type PR struct {
-	Number int `json:"number"`
-}
-
 type PackageJson struct {
 	Name string
 }
 
-type DistTags struct {
-	Latest string
-}
-
-type RegistryResponse struct {
-	DistTags DistTags `json:"dist-tags"`
-}
-
 var owner string
 var repo string
-var registry string
+var registryServer string
+var registryKind string
 var tag string
 var targetCommitish string
 var pr int
 var commit string
 var dryRun bool
 var kafkaTopic string
+var provider string
+var server string
+var changelogPath string
+var updateExisting bool
+var format string
+var serveAddr string
+var notifyFlagList notifyFlags
+var notifiers []notify.Notifier
+
+// notifyTimeout bounds how long a single notifier gets to deliver a
+// release announcement before it's logged as failed.
+const notifyTimeout = 10 * time.Second
+
+// notifyTarget is one kind=webhookURL pair parsed from a -notify flag.
+type notifyTarget struct {
+	kind string
+	url  string
+}
+
+// notifyFlags collects the repeatable -notify flag into a list of
+// notifyTargets.
+type notifyFlags []notifyTarget
+
+func (n *notifyFlags) String() string {
+	return fmt.Sprint([]notifyTarget(*n))
+}
+
+func (n *notifyFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("-notify expects kind=webhookURL (e.g. slack=$SLACK_WEBHOOK), got %q", value)
+	}
+	*n = append(*n, notifyTarget{kind: parts[0], url: parts[1]})
+	return nil
+}
 
 func init() {
 	flag.StringVar(&repo, "repo", "", "Specify single Github repo to check (required)")
 	flag.StringVar(&owner, "owner", "", "Specify Github owner to check (required)")
-	flag.StringVar(&registry, "registry", "", "Specify npm registry (required)")
+	flag.StringVar(&registryServer, "registry", "", "Specify a self-hosted registry URL override (npm only; default selects each registry's public hosted API)")
+	flag.StringVar(&registryKind, "registry-kind", "npm", "Specify package registry: npm, pypi, maven, cargo, or docker (docker: picks the most recently updated tag that parses as semver, skipping moving tags like \"latest\")")
 	flag.StringVar(&tag, "tag", "", "Specify release tag name (e.g. v1.0.2)")
 	flag.StringVar(&targetCommitish, "targetRef", "", "Specify target ref oid to tag")
 	flag.IntVar(&pr, "pr", 0, "List a PRs commits")
 	flag.StringVar(&commit, "commit", "master", "Specify commit ref oid to base everything off of (default: master)")
 	flag.BoolVar(&dryRun, "dry-run", false, "Show what the release would look like w/o publishing")
 	flag.StringVar(&kafkaTopic, "kafka-topic", "", "Specify kafka topic to subscribe to")
+	flag.StringVar(&provider, "provider", "github", "Specify SCM provider: github, gitlab, or gitea")
+	flag.StringVar(&server, "server", "", "Specify self-hosted SCM server URL (default: provider's hosted API)")
+	flag.StringVar(&changelogPath, "changelog-path", "CHANGELOG.md", "Specify path to a Keep a Changelog formatted changelog")
+	flag.BoolVar(&updateExisting, "update-existing", false, "Update the body of an already-published release instead of creating a new one")
+	flag.StringVar(&format, "format", "flat", "Specify release body format: flat or conventional")
+	flag.StringVar(&serveAddr, "serve", "", "Run an HTTP server on this address, publishing releases for merged PR webhook deliveries instead of Kafka")
+	flag.Var(&notifyFlagList, "notify", "Add a notifier target as kind=webhookURL (repeatable); kind is one of slack, discord, teams, webhook")
 }
 
 func main() {
@@ -170,14 +107,23 @@ func main() {
 		log.Fatal("owner is a required parameter")
 	}
 
-	if len(registry) == 0 {
-		flag.Usage()
-		log.Fatal("registry is a required parameter")
-	}
-
 	githubToken := os.Getenv("TOKEN")
 	bootstrapServers := os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
 
+	rmt, err := remote.New(provider, server, githubToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	notifiers, err = buildNotifiers(notifyFlagList)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if serveAddr != "" {
+		log.Fatal(serveWebhook(serveAddr, rmt))
+	}
+
 	if len(bootstrapServers) > 0 && len(kafkaTopic) > 0 {
 		err := subscribeToKafkaForRepoMessage(bootstrapServers, kafkaTopic, repo)
 		if err != nil {
@@ -185,64 +131,159 @@ func main() {
 		}
 	}
 
-	if len(tag) == 0 && len(repo) != 0 {
-		name, err := getNpmPackageName(githubToken, owner, repo)
+	if pr == 0 && len(repo) != 0 {
+		pr, err = rmt.PullRequestForCommit(owner, repo, commit)
 		if err != nil {
 			log.Fatal(err)
 		}
+	}
 
-		version, err := getLatestVersion(registry, name)
-		if err != nil {
+	if pr > 0 {
+		if err := publishRelease(rmt, owner, repo, pr); err != nil {
 			log.Fatal(err)
 		}
-		tag = "v" + version
+		os.Exit(0)
+	}
+}
 
+// buildNotifiers constructs the Notifier for every target configured via
+// -notify flags, then adds one for each supported kind whose environment
+// fallback (SLACK_WEBHOOK, DISCORD_WEBHOOK, TEAMS_WEBHOOK, WEBHOOK_URL) is
+// set and wasn't already configured via a flag.
+func buildNotifiers(targets []notifyTarget) ([]notify.Notifier, error) {
+	envFallbacks := map[string]string{
+		"slack":   os.Getenv("SLACK_WEBHOOK"),
+		"discord": os.Getenv("DISCORD_WEBHOOK"),
+		"teams":   os.Getenv("TEAMS_WEBHOOK"),
+		"webhook": os.Getenv("WEBHOOK_URL"),
 	}
 
-	var err error
-	if pr == 0 && len(repo) != 0 {
-		pr, err = getPullRequestNumber(githubToken, owner, repo, commit)
+	var built []notify.Notifier
+	configured := map[string]bool{}
+
+	for _, t := range targets {
+		n, err := notify.New(t.kind, t.url)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
+		built = append(built, n)
+		configured[t.kind] = true
 	}
 
-	if pr > 0 {
-		repository, err := getRepositoryPullRequest(githubToken, owner, repo, pr)
+	for _, kind := range []string{"slack", "discord", "teams", "webhook"} {
+		url := envFallbacks[kind]
+		if url == "" || configured[kind] {
+			continue
+		}
+		n, err := notify.New(kind, url)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
+		built = append(built, n)
+	}
 
-		var output string
-		for _, node := range repository.PullRequest.Commits.Nodes {
-			output += "* " + node.Commit.MessageHeadline + " @" + node.Commit.Author.User.Login + "\n"
-		}
+	return built, nil
+}
+
+// dispatchNotifications fans release out to every configured notifier
+// concurrently, bounding each by notifyTimeout and logging its outcome.
+// A notifier failing doesn't fail the run.
+func dispatchNotifications(owner, repo string, release remote.Release, author string) {
+	if len(notifiers) == 0 {
+		return
+	}
 
-		if tag == "" {
-			log.Fatal("Need to provide tag to publish release")
+	announcement := notify.Release{Tag: release.TagName, Body: release.Body, Author: author}
+	ref := notify.RepoRef{Owner: owner, Repo: repo}
+
+	var wg sync.WaitGroup
+	for _, n := range notifiers {
+		wg.Add(1)
+		go func(n notify.Notifier) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+
+			if err := n.Notify(ctx, announcement, ref); err != nil {
+				log.Printf("notify: %T failed: %v", n, err)
+				return
+			}
+			log.Printf("notify: %T delivered", n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+// publishRelease builds and publishes (or, in dry-run mode, prints) the
+// release for the given pull request, inferring a tag when one wasn't
+// supplied on the command line. It backs both the one-shot CLI flow and
+// the -serve webhook handler.
+func publishRelease(rmt remote.Remote, owner, repo string, pr int) error {
+	pullRequest, err := rmt.PullRequestWithCommits(owner, repo, pr)
+	if err != nil {
+		return err
+	}
+
+	var parsed []commits.Parsed
+	for _, c := range pullRequest.Commits {
+		parsed = append(parsed, commits.Parse(c.MessageHeadline, c.Body))
+	}
+
+	releaseTag := tag
+	if releaseTag == "" && len(repo) != 0 {
+		releaseTag, err = nextTag(rmt, owner, repo, parsed)
+		if err != nil {
+			return err
 		}
+	}
 
-		if targetCommitish == "" {
-			targetCommitish = repository.PullRequest.BaseRefName
+	var output string
+	switch format {
+	case "conventional":
+		output = renderConventional(parsed)
+	default:
+		for _, c := range pullRequest.Commits {
+			output += "* " + c.MessageHeadline + " @" + c.AuthorLogin + "\n"
 		}
+	}
 
-		release := Release{TagName: tag, TargetCommitish: targetCommitish, Name: tag, Body: output}
+	if releaseTag == "" {
+		return fmt.Errorf("need to provide tag to publish release")
+	}
 
-		if !dryRun {
-			err = publishRelease(githubToken, owner, repo, release)
-			if err != nil {
-				log.Fatal(err)
-			}
+	commitish := targetCommitish
+	if commitish == "" {
+		commitish = pullRequest.BaseRefName
+	}
+
+	if changelogContent, err := rmt.FileAtRef(owner, repo, commit, changelogPath); err == nil {
+		if entry, ok := changelog.Find(changelogContent, releaseTag); ok {
+			output = entry.Body + "\n"
 		}
+	}
 
-		fmt.Println(owner + "/" + repo + " - " + targetCommitish + ":" + tag)
-		fmt.Print(output)
+	release := remote.Release{TagName: releaseTag, TargetCommitish: commitish, Name: releaseTag, Body: output}
 
-		os.Exit(0)
+	if !dryRun {
+		if updateExisting {
+			err = rmt.UpdateRelease(owner, repo, release)
+		} else {
+			err = rmt.PublishRelease(owner, repo, release)
+		}
+		if err != nil {
+			return err
+		}
+		dispatchNotifications(owner, repo, release, pullRequest.AuthorLogin)
 	}
+
+	fmt.Println(owner + "/" + repo + " - " + commitish + ":" + releaseTag)
+	fmt.Print(output)
+
+	return nil
 }
 
-func subscribeToKafkaForRepoMessage(bootstrapServers, kafkaTopic, repo string) (error) {
+func subscribeToKafkaForRepoMessage(bootstrapServers, kafkaTopic, repo string) error {
 	c, err := kafka.NewConsumer(&kafka.ConfigMap{
 		"bootstrap.servers": bootstrapServers,
 		"group.id":          "release-changelog",
@@ -272,155 +313,186 @@ func subscribeToKafkaForRepoMessage(bootstrapServers, kafkaTopic, repo string) (
 	return nil
 }
 
-func getLatestVersion(registry, name string) (string, error) {
-	url := registry + "/" + name
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			}}}
-	req, err := http.NewRequest("GET", url, nil)
+// serveWebhook runs an HTTP server on addr that receives GitHub webhook
+// deliveries at /webhook, verifies their X-Hub-Signature-256 signature
+// against GITHUB_WEBHOOK_SECRET, and publishes a release whenever it sees
+// a merged pull_request event for the configured owner/repo. It gives
+// users a push-based alternative to subscribeToKafkaForRepoMessage that
+// doesn't require standing up a Kafka broker.
+func serveWebhook(addr string, rmt remote.Remote) error {
+	secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+	if secret == "" {
+		return fmt.Errorf("GITHUB_WEBHOOK_SECRET must be set to run in -serve mode")
+	}
+
+	http.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !webhook.VerifySignature([]byte(secret), body, r.Header.Get(webhook.SignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Header.Get(webhook.EventHeader) != "pull_request" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		event, err := webhook.ParsePullRequestEvent(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !event.Merged() || event.Repository.Owner.Login != owner || event.Repository.Name != repo {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if err := publishRelease(rmt, owner, repo, event.PullRequest.Number); err != nil {
+			log.Println(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	log.Printf("listening for webhook deliveries on %s", addr)
+	return http.ListenAndServe(addr, nil)
+}
+
+// nextTag infers the release tag from the package's current published
+// version, bumped according to the Conventional Commits found in parsed.
+func nextTag(rmt remote.Remote, owner, repo string, parsed []commits.Parsed) (string, error) {
+	reg, err := registry.New(registryKind, registryServer)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 
-	resp, err := client.Do(req)
+	name, err := getPackageName(rmt, owner, repo, registryKind)
 	if err != nil {
 		return "", err
 	}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	version, err := reg.LatestVersion(context.Background(), name)
 	if err != nil {
 		return "", err
 	}
 
-	var registryResponse RegistryResponse
-	err = json.Unmarshal(body, &registryResponse)
+	current, err := semver.NewVersion(version)
 	if err != nil {
 		return "", err
 	}
 
-	return registryResponse.DistTags.Latest, nil
+	next := commits.Bump(current, parsed)
+	return "v" + next.String(), nil
 }
 
-func getNpmPackageName(githubToken, owner, repo string) (string, error) {
-	client := *graphql.NewClient(
-		"https://api.github.com/graphql",
-		graphql.WithHTTPClient(&http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				}}}))
+// renderConventional renders parsed commits grouped into their
+// Conventional Commits sections.
+func renderConventional(parsed []commits.Parsed) string {
+	sections, buckets := commits.Group(parsed)
 
-	request := graphql.NewRequest(NpmPackageJsonQuery)
-	request.Var("owner", owner)
-	request.Var("repo", repo)
-	request.Header.Add("Authorization", "bearer "+githubToken)
+	var output string
+	for _, section := range sections {
+		output += "### " + section + "\n\n"
+		for _, c := range buckets[section] {
+			output += "* " + c.Subject + "\n"
+		}
+		output += "\n"
+	}
+	return output
+}
 
-	ctx := context.Background()
+// getPackageName auto-discovers the package identifier to look up in the
+// registry for kind, reading it out of the manifest file each ecosystem
+// conventionally publishes from. For docker no file is read: the
+// identifier is owner/repo.
+func getPackageName(rmt remote.Remote, owner, repo, kind string) (string, error) {
+	switch kind {
+	case "", "npm":
+		return getNpmPackageName(rmt, owner, repo)
+	case "pypi":
+		return getPyProjectName(rmt, owner, repo)
+	case "maven":
+		return getPomCoordinate(rmt, owner, repo)
+	case "cargo":
+		return getCargoTomlName(rmt, owner, repo)
+	case "docker":
+		return owner + "/" + repo, nil
+	default:
+		return "", fmt.Errorf("registry: unknown kind %q", kind)
+	}
+}
 
-	var respData QueryResponse
-	if err := client.Run(ctx, request, &respData); err != nil {
+func getNpmPackageName(rmt remote.Remote, owner, repo string) (string, error) {
+	text, err := rmt.FileAtRef(owner, repo, "master", "package.json")
+	if err != nil {
 		return "", err
 	}
 
 	var packageJson PackageJson
-	if err := json.Unmarshal([]byte(respData.Repository.Object.Text), &packageJson); err != nil {
+	if err := json.Unmarshal([]byte(text), &packageJson); err != nil {
 		return "", err
 	}
 	return packageJson.Name, nil
 }
 
-func getPullRequestNumber(githubToken, owner, repo, commmit string) (int, error) {
-	pr := 0
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			}}}
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/commits/" + commit + "/pulls"
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
-	req.Header.Add("Accept", "application/vnd.github.v3+json")
-	req.Header.Add("Authorization", "bearer "+githubToken)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return pr, err
-	}
-
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return pr, err
-	}
+var pyProjectNameRe = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
 
-	var PRs []PR
-	err = json.Unmarshal(body, &PRs)
+func getPyProjectName(rmt remote.Remote, owner, repo string) (string, error) {
+	text, err := rmt.FileAtRef(owner, repo, "master", "pyproject.toml")
 	if err != nil {
-		return pr, err
+		return "", err
 	}
 
-	if len(PRs) > 0 {
-		pr = PRs[0].Number
+	m := pyProjectNameRe.FindStringSubmatch(text)
+	if m == nil {
+		return "", fmt.Errorf("registry: no name found in pyproject.toml")
 	}
-
-	return pr, nil
+	return m[1], nil
 }
 
-func getRepositoryPullRequest(githubToken, owner, repo string, pr int) (Repository, error) {
-	client := *graphql.NewClient(
-		"https://api.github.com/graphql",
-		graphql.WithHTTPClient(&http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				}}}))
+var pomParentRe = regexp.MustCompile(`(?s)<parent>.*?</parent>`)
+var pomGroupIDRe = regexp.MustCompile(`<groupId>([^<]+)</groupId>`)
+var pomArtifactIDRe = regexp.MustCompile(`<artifactId>([^<]+)</artifactId>`)
 
-	request := graphql.NewRequest(PullRequestQuery)
-	request.Var("owner", owner)
-	request.Var("repo", repo)
-	request.Var("pr", pr)
-	request.Header.Add("Authorization", "bearer "+githubToken)
+func getPomCoordinate(rmt remote.Remote, owner, repo string) (string, error) {
+	text, err := rmt.FileAtRef(owner, repo, "master", "pom.xml")
+	if err != nil {
+		return "", err
+	}
 
-	ctx := context.Background()
+	// Strip the <parent> block first so its coordinates (usually listed
+	// before the project's own) aren't mistaken for the project's.
+	ownCoordinates := pomParentRe.ReplaceAllString(text, "")
 
-	var respData QueryResponse
-	if err := client.Run(ctx, request, &respData); err != nil {
-		return Repository{}, err
+	group := pomGroupIDRe.FindStringSubmatch(ownCoordinates)
+	artifact := pomArtifactIDRe.FindStringSubmatch(ownCoordinates)
+	if group == nil || artifact == nil {
+		return "", fmt.Errorf("registry: no groupId/artifactId found in pom.xml")
 	}
-
-	return respData.Repository, nil
+	return group[1] + ":" + artifact[1], nil
 }
 
-func publishRelease(githubToken, owner, repo string, release Release) error {
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			}}}
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/releases"
-	requestBody, err := json.Marshal(release)
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		log.Fatal(err)
-	}
-	req.Header.Add("Accept", "application/vnd.github.v3+json")
-	req.Header.Add("Authorization", "bearer "+githubToken)
+var cargoTomlNameRe = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
 
-	resp, err := client.Do(req)
+func getCargoTomlName(rmt remote.Remote, owner, repo string) (string, error) {
+	text, err := rmt.FileAtRef(owner, repo, "master", "Cargo.toml")
 	if err != nil {
-		return err
+		return "", err
 	}
-	resp.Body.Close()
 
-	return nil
+	m := cargoTomlNameRe.FindStringSubmatch(text)
+	if m == nil {
+		return "", fmt.Errorf("registry: no name found in Cargo.toml")
+	}
+	return m[1], nil
 }
 
 func Exists(name string) bool {
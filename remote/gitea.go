@@ -0,0 +1,210 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+const defaultGiteaServer = "https://gitea.com"
+
+type giteaPullRequest struct {
+	Index     int    `json:"number"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+	Base      struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Head struct {
+		Sha string `json:"sha"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+type giteaCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type giteaRelease struct {
+	TagName string `json:"tag_name"`
+	Target  string `json:"target_commitish"`
+	Title   string `json:"name"`
+	Note    string `json:"body"`
+}
+
+// Gitea talks to gitea.com or a self-hosted Gitea instance via the v1 REST
+// API.
+type Gitea struct {
+	server string
+	token  string
+}
+
+// NewGitea returns a Gitea remote. An empty server selects gitea.com.
+func NewGitea(server, token string) *Gitea {
+	if server == "" {
+		server = defaultGiteaServer
+	}
+	return &Gitea{server: server, token: token}
+}
+
+func (g *Gitea) httpClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			}}}
+}
+
+func (g *Gitea) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, g.server+"/api/v1"+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", "token "+g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (g *Gitea) PullRequestForCommit(owner, repo, sha string) (int, error) {
+	body, err := g.do("GET", "/repos/"+owner+"/"+repo+"/commits/"+sha+"/pull", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var pr giteaPullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return 0, err
+	}
+
+	return pr.Index, nil
+}
+
+func (g *Gitea) PullRequestWithCommits(owner, repo string, pr int) (PullRequest, error) {
+	prBody, err := g.do("GET", "/repos/"+owner+"/"+repo+"/pulls/"+strconv.Itoa(pr), nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var giteaPR giteaPullRequest
+	if err := json.Unmarshal(prBody, &giteaPR); err != nil {
+		return PullRequest{}, err
+	}
+
+	commitsBody, err := g.do("GET", "/repos/"+owner+"/"+repo+"/pulls/"+strconv.Itoa(pr)+"/commits", nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var commits []giteaCommit
+	if err := json.Unmarshal(commitsBody, &commits); err != nil {
+		return PullRequest{}, err
+	}
+
+	out := PullRequest{
+		Title:       giteaPR.Title,
+		CreatedAt:   giteaPR.CreatedAt,
+		BaseRefName: giteaPR.Base.Ref,
+		HeadRefOid:  giteaPR.Head.Sha,
+		AuthorLogin: giteaPR.User.Login,
+	}
+	for _, c := range commits {
+		headline, body := splitCommitMessage(c.Commit.Message)
+		out.Commits = append(out.Commits, Commit{
+			MessageHeadline: headline,
+			Body:            body,
+			AbbreviatedOid:  c.SHA[:7],
+			AuthorLogin:     c.Commit.Author.Name,
+		})
+	}
+	return out, nil
+}
+
+func (g *Gitea) PublishRelease(owner, repo string, release Release) error {
+	payload, err := json.Marshal(giteaRelease{
+		TagName: release.TagName,
+		Target:  release.TargetCommitish,
+		Title:   release.Name,
+		Note:    release.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = g.do("POST", "/repos/"+owner+"/"+repo+"/releases", payload)
+	return err
+}
+
+type giteaReleaseID struct {
+	ID int `json:"id"`
+}
+
+func (g *Gitea) releaseForTag(owner, repo, tag string) (giteaReleaseID, error) {
+	body, err := g.do("GET", "/repos/"+owner+"/"+repo+"/releases/tags/"+tag, nil)
+	if err != nil {
+		return giteaReleaseID{}, err
+	}
+
+	var release giteaReleaseID
+	if err := json.Unmarshal(body, &release); err != nil {
+		return giteaReleaseID{}, err
+	}
+	return release, nil
+}
+
+func (g *Gitea) ReleaseExists(owner, repo, tag string) (bool, error) {
+	release, err := g.releaseForTag(owner, repo, tag)
+	if err != nil {
+		return false, err
+	}
+	return release.ID != 0, nil
+}
+
+func (g *Gitea) UpdateRelease(owner, repo string, release Release) error {
+	existing, err := g.releaseForTag(owner, repo, release.TagName)
+	if err != nil {
+		return err
+	}
+	if existing.ID == 0 {
+		return fmt.Errorf("remote: no release found for tag %q", release.TagName)
+	}
+
+	payload, err := json.Marshal(giteaRelease{
+		TagName: release.TagName,
+		Target:  release.TargetCommitish,
+		Title:   release.Name,
+		Note:    release.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = g.do("PATCH", "/repos/"+owner+"/"+repo+"/releases/"+strconv.Itoa(existing.ID), payload)
+	return err
+}
+
+func (g *Gitea) FileAtRef(owner, repo, ref, path string) (string, error) {
+	body, err := g.do("GET", "/repos/"+owner+"/"+repo+"/raw/"+path+"?ref="+ref, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
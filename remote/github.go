@@ -0,0 +1,321 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/machinebox/graphql"
+)
+
+const defaultGitHubAPI = "https://api.github.com"
+const defaultGitHubGraphQL = "https://api.github.com/graphql"
+
+var pullRequestQuery = `
+query($owner: String!, $repo: String!, $pr: Int!) {
+  repository(owner: $owner, name: $repo) {
+    name
+    pullRequest(number: $pr) {
+      title baseRefName author { login } baseRefOid headRefOid createdAt
+      commits(first: 50) {
+        nodes {
+          commit {
+            messageHeadline
+            messageBody
+            abbreviatedOid
+            author { user { login } }
+            associatedPullRequests(first: 1) {
+              nodes {
+                number
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+var fileAtRefQuery = `
+query($owner: String!, $repo: String!, $expression: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(expression: $expression) {
+      ... on Blob {
+        text
+      }
+    }
+  }
+}
+`
+
+type ghUser struct {
+	Login string
+}
+
+type ghAuthor struct {
+	User ghUser
+}
+
+type ghAssociatedPullRequest struct {
+	Number int
+}
+
+type ghAssociatedPullRequests struct {
+	Nodes []ghAssociatedPullRequest `json:"nodes"`
+}
+
+type ghCommit struct {
+	MessageHeadline        string                   `json:"messageHeadline"`
+	MessageBody            string                   `json:"messageBody"`
+	AbbreviatedOid         string                   `json:"abbreviatedOid"`
+	Author                 ghAuthor                 `json:"author"`
+	AssociatedPullRequests ghAssociatedPullRequests `json:"associatedPullRequests"`
+}
+
+type ghCommitNodes struct {
+	Commit ghCommit
+}
+
+type ghCommits struct {
+	Nodes []ghCommitNodes
+}
+
+type ghPullRequestAuthor struct {
+	Login string
+}
+
+type ghPullRequest struct {
+	Title       string              `json:"title"`
+	CreatedAt   string              `json:"createdAt"`
+	BaseRefName string              `json:"baseRefName"`
+	HeadRefOid  string              `json:"headRefOid"`
+	Author      ghPullRequestAuthor `json:"author"`
+	Commits     ghCommits           `json:"commits"`
+}
+
+type ghObject struct {
+	Text string `json:"text"`
+}
+
+type ghRepository struct {
+	Name        string        `json:"name"`
+	PullRequest ghPullRequest `json:"pullRequest"`
+	Object      ghObject      `json:"object"`
+}
+
+type ghQueryResponse struct {
+	Repository ghRepository `json:"repository"`
+}
+
+type ghPR struct {
+	Number int `json:"number"`
+}
+
+// GitHub talks to github.com or a GitHub Enterprise Server instance.
+type GitHub struct {
+	apiURL     string
+	graphqlURL string
+	token      string
+}
+
+// NewGitHub returns a GitHub remote. An empty server selects github.com.
+func NewGitHub(server, token string) *GitHub {
+	if server == "" {
+		return &GitHub{apiURL: defaultGitHubAPI, graphqlURL: defaultGitHubGraphQL, token: token}
+	}
+	return &GitHub{apiURL: server + "/api/v3", graphqlURL: server + "/api/graphql", token: token}
+}
+
+func (g *GitHub) httpClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			}}}
+}
+
+func (g *GitHub) graphqlClient() *graphql.Client {
+	return graphql.NewClient(g.graphqlURL, graphql.WithHTTPClient(g.httpClient()))
+}
+
+func (g *GitHub) PullRequestForCommit(owner, repo, sha string) (int, error) {
+	url := g.apiURL + "/repos/" + owner + "/" + repo + "/commits/" + sha + "/pulls"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	req.Header.Add("Authorization", "bearer "+g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var prs []ghPR
+	if err := json.Unmarshal(body, &prs); err != nil {
+		return 0, err
+	}
+
+	if len(prs) > 0 {
+		return prs[0].Number, nil
+	}
+	return 0, nil
+}
+
+func (g *GitHub) PullRequestWithCommits(owner, repo string, pr int) (PullRequest, error) {
+	request := graphql.NewRequest(pullRequestQuery)
+	request.Var("owner", owner)
+	request.Var("repo", repo)
+	request.Var("pr", pr)
+	request.Header.Add("Authorization", "bearer "+g.token)
+
+	ctx := context.Background()
+
+	var respData ghQueryResponse
+	if err := g.graphqlClient().Run(ctx, request, &respData); err != nil {
+		return PullRequest{}, err
+	}
+
+	gpr := respData.Repository.PullRequest
+	out := PullRequest{
+		Title:       gpr.Title,
+		CreatedAt:   gpr.CreatedAt,
+		BaseRefName: gpr.BaseRefName,
+		HeadRefOid:  gpr.HeadRefOid,
+		AuthorLogin: gpr.Author.Login,
+	}
+	for _, node := range gpr.Commits.Nodes {
+		out.Commits = append(out.Commits, Commit{
+			MessageHeadline: node.Commit.MessageHeadline,
+			Body:            node.Commit.MessageBody,
+			AbbreviatedOid:  node.Commit.AbbreviatedOid,
+			AuthorLogin:     node.Commit.Author.User.Login,
+		})
+	}
+	return out, nil
+}
+
+func (g *GitHub) PublishRelease(owner, repo string, release Release) error {
+	url := g.apiURL + "/repos/" + owner + "/" + repo + "/releases"
+	requestBody, err := json.Marshal(release)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	req.Header.Add("Authorization", "bearer "+g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+type ghRelease struct {
+	ID int `json:"id"`
+}
+
+func (g *GitHub) releaseForTag(owner, repo, tag string) (ghRelease, error) {
+	url := g.apiURL + "/repos/" + owner + "/" + repo + "/releases/tags/" + tag
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return ghRelease{}, err
+	}
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	req.Header.Add("Authorization", "bearer "+g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return ghRelease{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ghRelease{}, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ghRelease{}, err
+	}
+
+	var release ghRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return ghRelease{}, err
+	}
+	return release, nil
+}
+
+func (g *GitHub) ReleaseExists(owner, repo, tag string) (bool, error) {
+	release, err := g.releaseForTag(owner, repo, tag)
+	if err != nil {
+		return false, err
+	}
+	return release.ID != 0, nil
+}
+
+func (g *GitHub) UpdateRelease(owner, repo string, release Release) error {
+	existing, err := g.releaseForTag(owner, repo, release.TagName)
+	if err != nil {
+		return err
+	}
+	if existing.ID == 0 {
+		return fmt.Errorf("remote: no release found for tag %q", release.TagName)
+	}
+
+	url := g.apiURL + "/repos/" + owner + "/" + repo + "/releases/" + strconv.Itoa(existing.ID)
+	requestBody, err := json.Marshal(release)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	req.Header.Add("Authorization", "bearer "+g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (g *GitHub) FileAtRef(owner, repo, ref, path string) (string, error) {
+	request := graphql.NewRequest(fileAtRefQuery)
+	request.Var("owner", owner)
+	request.Var("repo", repo)
+	request.Var("expression", ref+":"+path)
+	request.Header.Add("Authorization", "bearer "+g.token)
+
+	ctx := context.Background()
+
+	var respData ghQueryResponse
+	if err := g.graphqlClient().Run(ctx, request, &respData); err != nil {
+		return "", err
+	}
+
+	return respData.Repository.Object.Text, nil
+}
@@ -0,0 +1,210 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const defaultGitLabServer = "https://gitlab.com"
+
+type glMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	CreatedAt    string `json:"created_at"`
+	TargetBranch string `json:"target_branch"`
+	SHA          string `json:"sha"`
+	Author       struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+type glCommit struct {
+	ID         string `json:"id"`
+	ShortID    string `json:"short_id"`
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+	AuthorName string `json:"author_name"`
+}
+
+type glRelease struct {
+	TagName     string `json:"tag_name"`
+	Ref         string `json:"ref"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type glFile struct {
+	Content string `json:"content"`
+}
+
+// GitLab talks to gitlab.com or a self-hosted GitLab instance via the v4
+// REST API.
+type GitLab struct {
+	server string
+	token  string
+}
+
+// NewGitLab returns a GitLab remote. An empty server selects gitlab.com.
+func NewGitLab(server, token string) *GitLab {
+	if server == "" {
+		server = defaultGitLabServer
+	}
+	return &GitLab{server: server, token: token}
+}
+
+func (g *GitLab) httpClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			}}}
+}
+
+func (g *GitLab) projectID(owner, repo string) string {
+	return url.QueryEscape(owner + "/" + repo)
+}
+
+func (g *GitLab) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, g.server+"/api/v4"+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (g *GitLab) PullRequestForCommit(owner, repo, sha string) (int, error) {
+	path := "/projects/" + g.projectID(owner, repo) + "/repository/commits/" + sha + "/merge_requests"
+	body, err := g.do("GET", path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var mrs []glMergeRequest
+	if err := json.Unmarshal(body, &mrs); err != nil {
+		return 0, err
+	}
+
+	if len(mrs) > 0 {
+		return mrs[0].IID, nil
+	}
+	return 0, nil
+}
+
+func (g *GitLab) PullRequestWithCommits(owner, repo string, pr int) (PullRequest, error) {
+	projectID := g.projectID(owner, repo)
+
+	mrBody, err := g.do("GET", "/projects/"+projectID+"/merge_requests/"+strconv.Itoa(pr), nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var mr glMergeRequest
+	if err := json.Unmarshal(mrBody, &mr); err != nil {
+		return PullRequest{}, err
+	}
+
+	commitsBody, err := g.do("GET", "/projects/"+projectID+"/merge_requests/"+strconv.Itoa(pr)+"/commits", nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var glCommits []glCommit
+	if err := json.Unmarshal(commitsBody, &glCommits); err != nil {
+		return PullRequest{}, err
+	}
+
+	out := PullRequest{
+		Title:       mr.Title,
+		CreatedAt:   mr.CreatedAt,
+		BaseRefName: mr.TargetBranch,
+		HeadRefOid:  mr.SHA,
+		AuthorLogin: mr.Author.Username,
+	}
+	for _, c := range glCommits {
+		_, body := splitCommitMessage(c.Message)
+		out.Commits = append(out.Commits, Commit{
+			MessageHeadline: c.Title,
+			Body:            body,
+			AbbreviatedOid:  c.ShortID,
+			AuthorLogin:     c.AuthorName,
+		})
+	}
+	return out, nil
+}
+
+func (g *GitLab) PublishRelease(owner, repo string, release Release) error {
+	payload, err := json.Marshal(glRelease{
+		TagName:     release.TagName,
+		Ref:         release.TargetCommitish,
+		Name:        release.Name,
+		Description: release.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = g.do("POST", "/projects/"+g.projectID(owner, repo)+"/releases", payload)
+	return err
+}
+
+func (g *GitLab) ReleaseExists(owner, repo, tag string) (bool, error) {
+	body, err := g.do("GET", "/projects/"+g.projectID(owner, repo)+"/releases/"+url.QueryEscape(tag), nil)
+	if err != nil {
+		return false, err
+	}
+
+	var release glRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return false, err
+	}
+	return release.TagName == tag, nil
+}
+
+func (g *GitLab) UpdateRelease(owner, repo string, release Release) error {
+	payload, err := json.Marshal(glRelease{
+		TagName:     release.TagName,
+		Ref:         release.TargetCommitish,
+		Name:        release.Name,
+		Description: release.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = g.do("PUT", "/projects/"+g.projectID(owner, repo)+"/releases/"+url.QueryEscape(release.TagName), payload)
+	return err
+}
+
+func (g *GitLab) FileAtRef(owner, repo, ref, path string) (string, error) {
+	filePath := url.QueryEscape(path)
+	body, err := g.do("GET", "/projects/"+g.projectID(owner, repo)+"/repository/files/"+filePath+"?ref="+url.QueryEscape(ref), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var file glFile
+	if err := json.Unmarshal(body, &file); err != nil {
+		return "", err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,86 @@
+// Package remote abstracts the SCM-specific calls release-changelog needs
+// (looking up a pull request, reading its commits, publishing a release and
+// reading a file at a ref) behind a single Remote interface, so the rest of
+// the tool can stay provider-agnostic.
+package remote
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Commit is a single commit belonging to a pull request.
+type Commit struct {
+	MessageHeadline string
+	// Body is the commit message with its headline removed, e.g. the
+	// part of the message a "BREAKING CHANGE:" footer would live in.
+	Body           string
+	AbbreviatedOid string
+	AuthorLogin    string
+}
+
+// PullRequest is the subset of pull request data release-changelog needs to
+// build a release.
+type PullRequest struct {
+	Title       string
+	CreatedAt   string
+	BaseRefName string
+	HeadRefOid  string
+	AuthorLogin string
+	Commits     []Commit
+}
+
+// Release is the payload published to the SCM's releases API.
+type Release struct {
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Body            string `json:"body"`
+}
+
+// Remote is implemented by each supported SCM provider.
+type Remote interface {
+	// PullRequestForCommit returns the number of the pull request that
+	// introduced the given commit sha, or 0 if none is found.
+	PullRequestForCommit(owner, repo, sha string) (int, error)
+	// PullRequestWithCommits returns the pull request identified by pr,
+	// including its commits.
+	PullRequestWithCommits(owner, repo string, pr int) (PullRequest, error)
+	// PublishRelease creates a release on the remote.
+	PublishRelease(owner, repo string, release Release) error
+	// ReleaseExists reports whether a release already exists for tag.
+	ReleaseExists(owner, repo, tag string) (bool, error)
+	// UpdateRelease updates the body of the already-published release
+	// identified by release.TagName.
+	UpdateRelease(owner, repo string, release Release) error
+	// FileAtRef returns the contents of path as it exists at ref.
+	FileAtRef(owner, repo, ref, path string) (string, error)
+}
+
+// splitCommitMessage splits a raw commit message into its headline and
+// body, the way git itself does: the first line, then the remainder with
+// the blank line separating them stripped.
+func splitCommitMessage(message string) (headline, body string) {
+	parts := strings.SplitN(message, "\n", 2)
+	headline = parts[0]
+	if len(parts) == 2 {
+		body = strings.TrimPrefix(parts[1], "\n")
+	}
+	return headline, body
+}
+
+// New returns the Remote implementation for provider, talking to server
+// (the instance's base URL; each provider appends its own API path). An
+// empty server selects each provider's public, hosted API.
+func New(provider, server, token string) (Remote, error) {
+	switch provider {
+	case "", "github":
+		return NewGitHub(server, token), nil
+	case "gitlab":
+		return NewGitLab(server, token), nil
+	case "gitea":
+		return NewGitea(server, token), nil
+	default:
+		return nil, fmt.Errorf("remote: unknown provider %q", provider)
+	}
+}
@@ -0,0 +1,62 @@
+// Package webhook verifies and decodes GitHub webhook deliveries, so
+// release-changelog can react to merged pull requests without standing up
+// a Kafka broker.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// SignatureHeader is the header GitHub signs deliveries with.
+const SignatureHeader = "X-Hub-Signature-256"
+
+// EventHeader names the event type of a delivery.
+const EventHeader = "X-GitHub-Event"
+
+// VerifySignature reports whether signature (the raw X-Hub-Signature-256
+// header value) is a valid HMAC-SHA256 of body under secret.
+func VerifySignature(secret, body []byte, signature string) bool {
+	const prefix = "sha256="
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	expected := prefix + hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// PullRequestEvent is the subset of a GitHub "pull_request" delivery
+// release-changelog acts on.
+type PullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int  `json:"number"`
+		Merged bool `json:"merged"`
+	} `json:"pull_request"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+// ParsePullRequestEvent decodes a "pull_request" event payload.
+func ParsePullRequestEvent(body []byte) (PullRequestEvent, error) {
+	var event PullRequestEvent
+	err := json.Unmarshal(body, &event)
+	return event, err
+}
+
+// Merged reports whether event represents a pull request that was closed
+// by being merged, the trigger release-changelog publishes releases on.
+func (e PullRequestEvent) Merged() bool {
+	return e.Action == "closed" && e.PullRequest.Merged
+}